@@ -0,0 +1,53 @@
+// Package base 定义下载器扩展点之间共用的数据结构，供 download 包及各协议实现依赖
+package base
+
+// Status 描述一次下载任务所处的阶段
+type Status int
+
+const (
+	StatusReady Status = iota // 已创建，尚未开始
+	StatusStart                // 下载中
+	StatusPause                // 已暂停
+	StatusError                // 出错终止
+	StatusDone                 // 下载完成
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusReady:
+		return "ready"
+	case StatusStart:
+		return "start"
+	case StatusPause:
+		return "pause"
+	case StatusError:
+		return "error"
+	case StatusDone:
+		return "done"
+	default:
+		return "unknown"
+	}
+}
+
+// FileInfo 描述资源解析后对应的单个本地文件
+type FileInfo struct {
+	Name string // 文件名，不含目录
+	Ext  string // 扩展名，可能在 Resolve 阶段尚未知晓
+	Size int64  // 文件大小，<= 0 表示未知
+}
+
+// Resource 是 Fetcher.Resolve 的产物，描述了远程资源的可下载信息
+type Resource struct {
+	URI    string     // 原始请求地址
+	Files  []FileInfo // 该资源对应的一个或多个本地文件
+	Ranges bool       // 是否支持分片/断点续传
+}
+
+// Options 是创建下载任务时的可选配置
+type Options struct {
+	Dir         string                        // 目标目录
+	Connections int                           // 并行连接数，协议实现可忽略该字段
+	Timeout     int                           // 超时时间，单位秒
+	Retry       int                           // 失败重试次数
+	OnProgress  func(downloaded, total int64) // 聚合进度回调
+}
@@ -0,0 +1,70 @@
+/**
+* Package download 提供协议无关的下载扩展框架。
+* 核心下载逻辑被抽象为 Fetcher 接口，具体协议（HTTP/FTP/磁力链/BT 等）按 URL scheme
+* 注册到全局 registry 中，新增协议无需改动该包或调用方代码。
+ */
+package download
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+
+	"qq-zone/utils/download/base"
+)
+
+// Request 描述一次下载请求
+type Request struct {
+	URI     string
+	Headers map[string]string
+}
+
+// Fetcher 是每种下载协议必须实现的扩展点
+type Fetcher interface {
+	// Resolve 探测远程资源，返回文件清单与是否支持分片等信息
+	Resolve(req *Request) (*base.Resource, error)
+	// Create 依据 Resolve 得到的 Resource 及用户选项准备好下载任务
+	Create(res *base.Resource, opts *base.Options) error
+	Start() error
+	Pause() error
+	Continue() error
+	Close() error
+	// Status 返回任务当前所处的状态
+	Status() base.Status
+}
+
+// FetcherBuilder 返回该 Fetcher 实现支持的 URL scheme 列表，以及对应的构造函数
+type FetcherBuilder func() ([]string, func() Fetcher)
+
+var registry = struct {
+	mu       sync.RWMutex
+	builders map[string]func() Fetcher
+}{builders: make(map[string]func() Fetcher)}
+
+// Register 以 FetcherBuilder 声明的 scheme 注册一个 Fetcher 实现，
+// 通常在实现该协议的包的 init() 中调用
+func Register(builder FetcherBuilder) {
+	schemes, newFetcher := builder()
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	for _, scheme := range schemes {
+		registry.builders[scheme] = newFetcher
+	}
+}
+
+// New 依据 uri 的 scheme 查找已注册的 Fetcher 实现并返回一个新实例
+func New(uri string) (Fetcher, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	registry.mu.RLock()
+	newFetcher, ok := registry.builders[u.Scheme]
+	registry.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("download: no fetcher registered for scheme %q", u.Scheme)
+	}
+	return newFetcher(), nil
+}
@@ -0,0 +1,37 @@
+package download
+
+import (
+	"testing"
+
+	"qq-zone/utils/download/base"
+)
+
+type stubFetcher struct{}
+
+func (stubFetcher) Resolve(req *Request) (*base.Resource, error) { return &base.Resource{URI: req.URI}, nil }
+func (stubFetcher) Create(res *base.Resource, opts *base.Options) error { return nil }
+func (stubFetcher) Start() error                                       { return nil }
+func (stubFetcher) Pause() error                                       { return nil }
+func (stubFetcher) Continue() error                                    { return nil }
+func (stubFetcher) Close() error                                       { return nil }
+func (stubFetcher) Status() base.Status                                { return base.StatusReady }
+
+func TestRegisterAndNew(t *testing.T) {
+	Register(func() ([]string, func() Fetcher) {
+		return []string{"stub"}, func() Fetcher { return stubFetcher{} }
+	})
+
+	fetcher, err := New("stub://example.com/file")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if fetcher == nil {
+		t.Fatal("New() returned a nil Fetcher for a registered scheme")
+	}
+}
+
+func TestNewUnregisteredScheme(t *testing.T) {
+	if _, err := New("ftp://example.com/file"); err == nil {
+		t.Fatal("New() should error for an unregistered scheme")
+	}
+}
@@ -0,0 +1,119 @@
+// Package http 是 download.Fetcher 的 HTTP/HTTPS 实现，内部复用 qq-zone/utils/net/http
+// 既有的 Get/Download/Fetcher 逻辑，对外表现为 download 框架的一个标准协议插件
+package http
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"qq-zone/utils/download"
+	"qq-zone/utils/download/base"
+	nethttp "qq-zone/utils/net/http"
+)
+
+func init() {
+	download.Register(FetcherBuilder)
+}
+
+// FetcherBuilder 声明该实现支持的 scheme，供 download.Register 注册
+func FetcherBuilder() ([]string, func() download.Fetcher) {
+	return []string{"http", "https"}, func() download.Fetcher {
+		return &httpFetcher{}
+	}
+}
+
+type httpFetcher struct {
+	req     *download.Request
+	res     *base.Resource
+	opts    *base.Options
+	status  base.Status
+	fetcher *nethttp.Fetcher
+	cancel  context.CancelFunc
+}
+
+func (f *httpFetcher) Resolve(req *download.Request) (*base.Resource, error) {
+	f.req = req
+
+	resp, err := nethttp.Head(req.URI, req.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	f.res = &base.Resource{
+		URI:    req.URI,
+		Ranges: resp.AcceptsRanges,
+		Files: []base.FileInfo{{
+			Name: filepath.Base(req.URI),
+			Size: resp.ContentLength,
+		}},
+	}
+	return f.res, nil
+}
+
+func (f *httpFetcher) Create(res *base.Resource, opts *base.Options) error {
+	if len(res.Files) != 1 {
+		return fmt.Errorf("http fetcher: expected exactly one file, got %d", len(res.Files))
+	}
+	f.res = res
+	f.opts = opts
+
+	target := filepath.Join(opts.Dir, res.Files[0].Name)
+	f.fetcher = nethttp.NewFetcher(res.URI, target, opts.Connections, opts.Timeout, opts.OnProgress)
+	f.fetcher.Headers = f.req.Headers
+	f.status = base.StatusReady
+	return nil
+}
+
+func (f *httpFetcher) Start() error {
+	if f.fetcher == nil {
+		return fmt.Errorf("http fetcher: Start called before Create")
+	}
+	f.status = base.StatusStart
+
+	var err error
+	for attempt := 0; attempt <= f.opts.Retry; attempt++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		f.cancel = cancel
+
+		// 已下载的分片进度保存在 .gpd 存档中，重试时由 Fetcher.Start 自动续传而非从头开始
+		if err = f.fetcher.Start(ctx); err == nil {
+			f.status = base.StatusDone
+			return nil
+		}
+	}
+	f.status = base.StatusError
+	return err
+}
+
+func (f *httpFetcher) Pause() error {
+	if f.fetcher == nil {
+		return fmt.Errorf("http fetcher: Pause called before Create")
+	}
+	f.fetcher.Pause()
+	f.status = base.StatusPause
+	return nil
+}
+
+func (f *httpFetcher) Continue() error {
+	if f.fetcher == nil {
+		return fmt.Errorf("http fetcher: Continue called before Create")
+	}
+	f.fetcher.Resume()
+	f.status = base.StatusStart
+	return nil
+}
+
+func (f *httpFetcher) Close() error {
+	if f.cancel != nil {
+		f.cancel()
+	}
+	if f.fetcher != nil {
+		f.fetcher.Cancel()
+	}
+	return nil
+}
+
+func (f *httpFetcher) Status() base.Status {
+	return f.status
+}
@@ -0,0 +1,68 @@
+// Package helper 提供在多个包之间复用的小工具函数
+package helper
+
+import (
+	"encoding/hex"
+	"strings"
+	"sync"
+)
+
+// signature 描述一种文件类型的魔数前缀，prefix 为大写十六进制，"??" 表示该字节忽略匹配
+type signature struct {
+	prefix string
+	ext    string
+}
+
+var fileSignatures = newFileSignatures()
+
+func newFileSignatures() *sync.Map {
+	m := new(sync.Map)
+	for _, sig := range []signature{
+		{"FFD8FFE0", ".jpg"},
+		{"FFD8FFE1", ".jpg"},
+		{"89504E470D0A1A0A", ".png"},
+		{"474946383761", ".gif"},
+		{"474946383961", ".gif"},
+		{"????????66747970", ".mp4"},
+		{"52494646????????57454250", ".webp"},
+		{"504B0304", ".zip"},
+		{"25504446", ".pdf"},
+	} {
+		m.Store(sig.prefix, sig.ext)
+	}
+	return m
+}
+
+/**
+* DetectFileType 通过魔数（文件头的前若干字节）识别文件类型，返回带点号的扩展名，
+* 未能识别时返回空字符串。用于 Content-Type 缺失或为 application/octet-stream 等
+* 通用值时，推断远程文件的真实扩展名（常见于 QQ 空间的图片/视频 CDN）
+ */
+func DetectFileType(head []byte) string {
+	encoded := strings.ToUpper(hex.EncodeToString(head))
+
+	var ext string
+	fileSignatures.Range(func(key, value interface{}) bool {
+		if matchSignature(encoded, key.(string)) {
+			ext = value.(string)
+			return false
+		}
+		return true
+	})
+	return ext
+}
+
+func matchSignature(encoded, prefix string) bool {
+	if len(encoded) < len(prefix) {
+		return false
+	}
+	for i := 0; i < len(prefix); i += 2 {
+		if prefix[i:i+2] == "??" {
+			continue
+		}
+		if encoded[i:i+2] != prefix[i:i+2] {
+			return false
+		}
+	}
+	return true
+}
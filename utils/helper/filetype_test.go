@@ -0,0 +1,30 @@
+package helper
+
+import "testing"
+
+func TestDetectFileType(t *testing.T) {
+	cases := []struct {
+		name string
+		head []byte
+		want string
+	}{
+		{"jpeg", []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10}, ".jpg"},
+		{"png", []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}, ".png"},
+		{"gif87a", []byte("GIF87a"), ".gif"},
+		{"gif89a", []byte("GIF89a"), ".gif"},
+		{"zip", []byte{0x50, 0x4B, 0x03, 0x04}, ".zip"},
+		{"pdf", []byte("%PDF-1.4"), ".pdf"},
+		{"mp4", []byte{0x00, 0x00, 0x00, 0x18, 0x66, 0x74, 0x79, 0x70, 0x69, 0x73, 0x6F, 0x6D}, ".mp4"},
+		{"webp", append([]byte("RIFF"), append([]byte{0x00, 0x00, 0x00, 0x00}, []byte("WEBP")...)...), ".webp"},
+		{"unknown", []byte("not a known file type"), ""},
+		{"empty", nil, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := DetectFileType(c.head); got != c.want {
+				t.Errorf("DetectFileType(%q) = %q, want %q", c.name, got, c.want)
+			}
+		})
+	}
+}
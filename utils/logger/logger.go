@@ -1,56 +1,226 @@
+/**
+* Package logger 为长期运行的爬虫进程提供带级别、结构化字段与滚动归档的日志能力。
+* 写入通过带缓冲的 channel 交给单独的 goroutine，由它逐条写入保持打开的日志文件，避免每次调用都打开/关闭文件。
+ */
 package logger
 
 import (
-	"errors"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 )
 
+// Level 是日志级别，数值越大级别越高
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelFatal
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	case LevelFatal:
+		return "FATAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Sink 是日志输出目的地，可通过按位或组合多个
+type Sink int
+
 const (
-	DEFAULT_PATH string = "storage/logs/log.log" // 日志默认保存路径
+	SinkFile Sink = 1 << iota
+	SinkStdout
+	SinkSyslog
 )
 
-type Logger struct {}
+// Field 是一个结构化字段
+type Field struct {
+	Key string
+	Val interface{}
+}
+
+// Config 描述一个 Logger 实例的行为
+type Config struct {
+	Path       string // 日志文件路径，SinkFile 未启用时忽略
+	Level      Level  // 低于该级别的日志会被丢弃
+	Sinks      Sink   // 输出目的地，可组合，默认 SinkFile
+	MaxSizeMB  int64  // 单个日志文件达到该大小后滚动，<= 0 表示不按大小滚动
+	MaxBackups int    // 最多保留的归档文件数，<= 0 表示不清理
+	Compress   bool   // 滚动后的归档文件是否 gzip 压缩
+	BufferSize int    // 写入 channel 的缓冲区大小
+}
 
-func (l *Logger) record(msg interface{}, target string) error {
-	entension := filepath.Ext(target)
-	if entension == "" {
-		return errors.New(fmt.Sprintf("File name cannot be empty %s", target))
+// DEFAULT_PATH 保留自旧版 API，作为 DefaultConfig 的默认日志路径
+const DEFAULT_PATH string = "storage/logs/log.log"
+
+// DefaultConfig 返回开箱即用的默认配置：仅写文件、Info 级别、100MB 滚动、保留 7 份归档
+func DefaultConfig() *Config {
+	return &Config{
+		Path:       DEFAULT_PATH,
+		Level:      LevelInfo,
+		Sinks:      SinkFile,
+		MaxSizeMB:  100,
+		MaxBackups: 7,
+		Compress:   true,
+		BufferSize: 1024,
 	}
+}
 
-	dir := filepath.Dir(target)
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-		return err
+type record struct {
+	level  Level
+	msg    string
+	fields []Field
+	time   time.Time
+}
+
+// fileState 持有底层日志文件的可变状态，由父 Logger 与其 With() 派生的子 Logger 共享，
+// 避免复制 Logger 时连带复制锁却各自指向同一份文件句柄
+type fileState struct {
+	mu   sync.Mutex
+	file *os.File
+	size int64
+	day  int
+}
+
+// Logger 是支持分级、结构化字段与滚动归档的日志记录器
+type Logger struct {
+	cfg    *Config
+	fields []Field
+
+	ch   chan record
+	done chan struct{}
+
+	state *fileState
+}
+
+// New 依据 cfg 创建一个 Logger 并启动其后台写入 goroutine，cfg 为 nil 时使用 DefaultConfig
+func New(cfg *Config) *Logger {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = 1024
+	}
+	if cfg.Sinks == 0 {
+		cfg.Sinks = SinkFile
 	}
 
-	file, err := os.OpenFile(target, os.O_APPEND|os.O_CREATE, 666)
-	if err != nil {
-		return errors.New(fmt.Sprintf("Could not create fileer %s", target))
+	l := &Logger{
+		cfg:   cfg,
+		ch:    make(chan record, cfg.BufferSize),
+		done:  make(chan struct{}),
+		state: &fileState{},
 	}
-	defer file.Close()
+	go l.run()
+	return l
+}
 
-	logger := log.New(file, "", log.LstdFlags)
-	logger.Println(msg)
-	return nil
+func (l *Logger) run() {
+	for r := range l.ch {
+		l.write(r)
+	}
+	close(l.done)
+}
+
+func (l *Logger) write(r record) {
+	line := format(r)
+
+	if l.cfg.Sinks&SinkStdout != 0 {
+		fmt.Print(line)
+	}
+	if l.cfg.Sinks&SinkSyslog != 0 {
+		writeSyslog(r.level, line)
+	}
+	if l.cfg.Sinks&SinkFile != 0 {
+		l.writeFile(line)
+	}
+}
+
+func format(r record) string {
+	line := fmt.Sprintf("[%s] %s %s", r.time.Format("2006-01-02 15:04:05"), r.level, r.msg)
+	for _, f := range r.fields {
+		line += fmt.Sprintf(" %s=%v", f.Key, f.Val)
+	}
+	return line + "\n"
+}
+
+// With 返回一个携带了额外结构化字段的 Logger，原 Logger 不受影响
+func (l *Logger) With(key string, val interface{}) *Logger {
+	fields := make([]Field, len(l.fields), len(l.fields)+1)
+	copy(fields, l.fields)
+	fields = append(fields, Field{Key: key, Val: val})
+
+	child := *l
+	child.fields = fields
+	return &child // state is a shared pointer, so file/rotation bookkeeping stays in sync with l
 }
 
-func makepath(args ...interface{}) string {
-	target := DEFAULT_PATH
+func (l *Logger) log(level Level, msg string, args ...interface{}) {
+	if level < l.cfg.Level {
+		return
+	}
 	if len(args) > 0 {
-		target = args[0].(string)
+		msg = fmt.Sprintf(msg, args...)
+	}
+	l.ch <- record{level: level, msg: msg, fields: l.fields, time: time.Now()}
+}
+
+func (l *Logger) Debug(msg string, args ...interface{}) { l.log(LevelDebug, msg, args...) }
+func (l *Logger) Info(msg string, args ...interface{})  { l.log(LevelInfo, msg, args...) }
+func (l *Logger) Warn(msg string, args ...interface{})  { l.log(LevelWarn, msg, args...) }
+func (l *Logger) Error(msg string, args ...interface{}) { l.log(LevelError, msg, args...) }
+
+// Fatal 记录一条 Fatal 级别日志，刷新并关闭 Logger 后终止进程
+func (l *Logger) Fatal(msg string, args ...interface{}) {
+	l.log(LevelFatal, msg, args...)
+	l.Close()
+	os.Exit(1)
+}
+
+// Close 排空待写入的日志并关闭底层文件句柄
+func (l *Logger) Close() error {
+	close(l.ch)
+	<-l.done
+
+	l.state.mu.Lock()
+	defer l.state.mu.Unlock()
+	if l.state.file != nil {
+		err := l.state.file.Close()
+		l.state.file = nil
+		return err
 	}
-	return target
+	return nil
 }
 
+var std = New(DefaultConfig())
+
+// Info 是旧版 API 的薄封装，保留原有函数签名，写入包级默认 Logger；
+// args 作为 msg 的格式化参数，不再被当作日志文件路径
+func Info(msg interface{}, args ...interface{}) {
+	std.Info(fmt.Sprint(msg), args...)
+}
+
+// Println 等价于 Info，沿用自旧版 API
 func Println(msg interface{}, args ...interface{}) {
 	Info(msg, args...)
 }
 
-func Info(msg interface{}, args ...interface{}) {
-	if err := new(Logger).record(msg, makepath(args...)); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-}
\ No newline at end of file
+func ensureDir(path string) error {
+	return os.MkdirAll(filepath.Dir(path), os.ModePerm)
+}
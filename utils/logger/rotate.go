@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+func (l *Logger) writeFile(line string) {
+	l.state.mu.Lock()
+	defer l.state.mu.Unlock()
+
+	if l.state.file == nil {
+		if err := l.openFile(); err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+	if l.shouldRotate() {
+		l.rotate()
+	}
+
+	n, err := l.state.file.WriteString(line)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	l.state.size += int64(n)
+}
+
+func (l *Logger) openFile() error {
+	if err := ensureDir(l.cfg.Path); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(l.cfg.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o666)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err == nil {
+		l.state.size = info.Size()
+	}
+	l.state.file = file
+	l.state.day = time.Now().Day()
+	return nil
+}
+
+func (l *Logger) shouldRotate() bool {
+	maxBytes := l.cfg.MaxSizeMB * 1024 * 1024
+	return (maxBytes > 0 && l.state.size >= maxBytes) || time.Now().Day() != l.state.day
+}
+
+// rotate 将当前日志文件重命名为带时间戳的归档文件，按需 gzip 压缩并清理过期归档
+func (l *Logger) rotate() {
+	l.state.file.Close()
+
+	rotated := fmt.Sprintf("%s.%s", l.cfg.Path, time.Now().Format("20060102150405"))
+	if err := os.Rename(l.cfg.Path, rotated); err != nil {
+		fmt.Println(err)
+	} else if l.cfg.Compress {
+		if err := gzipFile(rotated); err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	l.pruneBackups()
+	if err := l.openFile(); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func (l *Logger) pruneBackups() {
+	if l.cfg.MaxBackups <= 0 {
+		return
+	}
+
+	matches, err := filepath.Glob(l.cfg.Path + ".*")
+	if err != nil {
+		return
+	}
+	sort.Strings(matches)
+
+	if excess := len(matches) - l.cfg.MaxBackups; excess > 0 {
+		for _, old := range matches[:excess] {
+			os.Remove(old)
+		}
+	}
+}
+
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	gw := gzip.NewWriter(dst)
+	if _, err := io.Copy(gw, src); err != nil {
+		gw.Close()
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
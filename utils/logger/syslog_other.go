@@ -0,0 +1,6 @@
+//go:build windows
+
+package logger
+
+// writeSyslog is a no-op on platforms without a native syslog facility.
+func writeSyslog(level Level, line string) {}
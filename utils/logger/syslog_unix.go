@@ -0,0 +1,33 @@
+//go:build !windows
+
+package logger
+
+import (
+	"log/syslog"
+	"sync"
+)
+
+var (
+	syslogOnce   sync.Once
+	syslogWriter *syslog.Writer
+)
+
+func writeSyslog(level Level, line string) {
+	syslogOnce.Do(func() {
+		syslogWriter, _ = syslog.New(syslog.LOG_INFO, "qq-zone")
+	})
+	if syslogWriter == nil {
+		return
+	}
+
+	switch level {
+	case LevelDebug:
+		syslogWriter.Debug(line)
+	case LevelInfo:
+		syslogWriter.Info(line)
+	case LevelWarn:
+		syslogWriter.Warning(line)
+	default:
+		syslogWriter.Err(line)
+	}
+}
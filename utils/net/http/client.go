@@ -0,0 +1,81 @@
+package http
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"time"
+)
+
+func tlsConfig(insecureSkipVerify bool) *tls.Config {
+	return &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+}
+
+// defaultTimeout 是 Get 与 Head 每次请求使用的超时秒数
+const defaultTimeout = 30
+
+// ClientOptions 配置一个 Client 实例
+type ClientOptions struct {
+	MaxIdleConnsPerHost int    // <= 0 时使用 16
+	Proxy               string // 显式代理地址，留空时回退到 http.ProxyFromEnvironment
+	InsecureSkipVerify  bool   // 是否跳过证书校验，仅在明确知道对端证书链不完整时才应开启
+	Timeout             int    // http.Client.Timeout，单位秒，<= 0 表示不设置客户端级别的总超时，沿用各调用方自行通过 context 控制的超时
+}
+
+/**
+* Client 封装一个共享的 *http.Transport 与 *http.Client，提供连接池复用、
+* 跨请求的 Cookie 会话（QQ 空间登录所需）与可配置的代理。
+* 不同账号可各自持有一个 Client 实例以隔离 Cookie 与代理。
+ */
+type Client struct {
+	http *http.Client
+}
+
+// NewClient 依据 opts 创建一个独立的 Client
+func NewClient(opts ClientOptions) (*Client, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	maxIdleConnsPerHost := opts.MaxIdleConnsPerHost
+	if maxIdleConnsPerHost <= 0 {
+		maxIdleConnsPerHost = 16
+	}
+
+	transport := &http.Transport{
+		MaxIdleConnsPerHost: maxIdleConnsPerHost,
+		ForceAttemptHTTP2:   true,
+		Proxy:               http.ProxyFromEnvironment,
+	}
+	transport.TLSClientConfig = tlsConfig(opts.InsecureSkipVerify)
+
+	if opts.Proxy != "" {
+		proxyURL, err := url.Parse(opts.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	client := &http.Client{Transport: transport, Jar: jar}
+	if opts.Timeout > 0 {
+		client.Timeout = time.Duration(opts.Timeout) * time.Second
+	}
+
+	return &Client{http: client}, nil
+}
+
+func mustNewClient(opts ClientOptions) *Client {
+	client, err := NewClient(opts)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// DefaultClient 是 Get/Download/Head 在未指定独立 Client 时共用的包级 Client。
+// 证书校验默认开启，需要跳过校验（如对接证书链不完整的站点）的调用方应通过
+// NewClient(ClientOptions{InsecureSkipVerify: true}) 显式创建自己的 Client
+var DefaultClient = mustNewClient(ClientOptions{})
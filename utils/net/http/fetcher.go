@@ -0,0 +1,326 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ProgressFunc 用于汇报下载的聚合进度，downloaded/total 单位均为字节
+type ProgressFunc func(downloaded, total int64)
+
+// chunk 描述单个分片的下载范围及已完成的进度
+type chunk struct {
+	Offset     int64 `json:"offset"`
+	End        int64 `json:"end"`
+	Downloaded int64 `json:"downloaded"`
+}
+
+// gpdState 是 .gpd 进度存档文件的内容，记录文件总大小与各分片进度
+type gpdState struct {
+	Size   int64   `json:"size"`
+	Chunks []chunk `json:"chunks"`
+}
+
+/**
+* Fetcher 基于 HTTP Range 请求实现多连接并行下载，支持暂停/恢复/取消与断点续传
+* 当目标服务器不支持 Range 时会自动退化为单连接的 Download
+ */
+type Fetcher struct {
+	URI         string
+	Target      string
+	Connections int
+	Timeout     int
+	OnProgress  ProgressFunc
+	Client      *Client           // 发起请求所用的 Client，nil 时回退到 DefaultClient
+	Headers     map[string]string // 附加到每个探测/分片请求上的自定义请求头
+
+	mu     sync.Mutex
+	size   int64
+	ranges bool
+	state  *gpdState
+
+	paused bool
+	pause  chan struct{}
+	resume chan struct{}
+	cancel context.CancelFunc
+}
+
+// NewFetcher 创建一个使用 DefaultClient 的多连接下载器，connections <= 0 时使用默认的 4 个并发连接
+func NewFetcher(uri, target string, connections int, timeout int, onProgress ProgressFunc) *Fetcher {
+	return NewFetcherWithClient(DefaultClient, uri, target, connections, timeout, onProgress)
+}
+
+// NewFetcherWithClient 创建一个多连接下载器，所有请求都经由传入的 client 发起，
+// 供需要独立 Cookie/代理（如不同 QQ 账号）的调用方使用；client 为 nil 时回退到 DefaultClient
+func NewFetcherWithClient(client *Client, uri, target string, connections int, timeout int, onProgress ProgressFunc) *Fetcher {
+	if client == nil {
+		client = DefaultClient
+	}
+	if connections <= 0 {
+		connections = 4
+	}
+	if timeout <= 0 {
+		timeout = 300
+	}
+	return &Fetcher{
+		URI:         uri,
+		Target:      target,
+		Connections: connections,
+		Timeout:     timeout,
+		OnProgress:  onProgress,
+		Client:      client,
+		resume:      make(chan struct{}),
+	}
+}
+
+func (f *Fetcher) sidecar() string {
+	return f.Target + ".gpd"
+}
+
+// probe 通过 Head 探测服务器是否支持 Range 以及资源总大小
+func (f *Fetcher) probe() error {
+	info, err := f.Client.Head(f.URI, f.Headers)
+	if err != nil {
+		return err
+	}
+	f.ranges = info.AcceptsRanges
+	f.size = info.ContentLength
+	return nil
+}
+
+// buildState 将 [0, size) 均分为 Connections 个连续分片
+func (f *Fetcher) buildState() *gpdState {
+	state := &gpdState{Size: f.size}
+	chunkSize := f.size / int64(f.Connections)
+	offset := int64(0)
+	for i := 0; i < f.Connections; i++ {
+		end := offset + chunkSize
+		if i == f.Connections-1 || end > f.size {
+			end = f.size
+		}
+		state.Chunks = append(state.Chunks, chunk{Offset: offset, End: end})
+		offset = end
+	}
+	return state
+}
+
+func (f *Fetcher) loadState() *gpdState {
+	data, err := os.ReadFile(f.sidecar())
+	if err != nil {
+		return nil
+	}
+	state := new(gpdState)
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil
+	}
+	return state
+}
+
+func (f *Fetcher) saveState() error {
+	f.mu.Lock()
+	data, err := json.Marshal(f.state)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f.sidecar(), data, 0o666)
+}
+
+func (f *Fetcher) reportProgress() {
+	if f.OnProgress == nil {
+		return
+	}
+	f.mu.Lock()
+	var downloaded int64
+	for _, c := range f.state.Chunks {
+		downloaded += c.Downloaded
+	}
+	total := f.size
+	f.mu.Unlock()
+	f.OnProgress(downloaded, total)
+}
+
+// Start 开始下载，ctx 用于支持 Cancel；如服务器不支持 Range 则退化为单连接 Download
+func (f *Fetcher) Start(ctx context.Context) error {
+	if f.Client == nil {
+		f.Client = DefaultClient
+	}
+	if err := f.probe(); err != nil {
+		return err
+	}
+
+	if !f.ranges || f.size <= 0 {
+		_, err := f.Client.Download(f.URI, f.Target, 0, f.Timeout, false)
+		return err
+	}
+
+	if state := f.loadState(); state != nil && state.Size == f.size {
+		f.state = state
+	} else {
+		f.state = f.buildState()
+	}
+
+	file, err := os.OpenFile(f.Target, os.O_RDWR|os.O_CREATE, 0o666)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := file.Truncate(f.size); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	f.cancel = cancel
+
+	g, gctx := errgroup.WithContext(ctx)
+	for i := range f.state.Chunks {
+		i := i
+		g.Go(func() error {
+			return f.downloadChunk(gctx, file, i)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	os.Remove(f.sidecar())
+	return nil
+}
+
+func (f *Fetcher) downloadChunk(ctx context.Context, file *os.File, idx int) error {
+	c := &f.state.Chunks[idx]
+
+	for {
+		if c.Offset+c.Downloaded >= c.End {
+			return nil
+		}
+
+		if err := f.waitIfPaused(ctx); err != nil {
+			return err
+		}
+
+		start := c.Offset + c.Downloaded
+		reqCtx, cancel := context.WithTimeout(ctx, time.Second*time.Duration(f.Timeout))
+		req, err := http.NewRequestWithContext(reqCtx, "GET", f.URI, nil)
+		if err != nil {
+			cancel()
+			return err
+		}
+		for key, val := range f.Headers {
+			req.Header.Set(key, val)
+		}
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, c.End-1))
+
+		resp, err := f.Client.http.Do(req)
+		if err != nil {
+			cancel()
+			return err
+		}
+
+		err = f.drainChunk(ctx, resp.Body, file, c, start)
+		resp.Body.Close()
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (f *Fetcher) drainChunk(ctx context.Context, body io.Reader, file *os.File, c *chunk, offset int64) error {
+	buf := make([]byte, 32*1024)
+	for {
+		if err := f.waitIfPaused(ctx); err != nil {
+			return err
+		}
+
+		n, rerr := body.Read(buf)
+		if n > 0 {
+			if _, werr := file.WriteAt(buf[:n], offset); werr != nil {
+				return werr
+			}
+			offset += int64(n)
+
+			f.mu.Lock()
+			c.Downloaded += int64(n)
+			f.mu.Unlock()
+
+			f.reportProgress()
+			if err := f.saveState(); err != nil {
+				return err
+			}
+		}
+		if rerr == io.EOF {
+			return nil
+		}
+		if rerr != nil {
+			return rerr
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+}
+
+func (f *Fetcher) waitIfPaused(ctx context.Context) error {
+	f.mu.Lock()
+	pauseCh := f.pause
+	resumeCh := f.resume
+	f.mu.Unlock()
+	if pauseCh == nil {
+		return nil
+	}
+	select {
+	case <-pauseCh:
+		select {
+		case <-resumeCh:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	default:
+		return nil
+	}
+}
+
+// Pause 暂停所有分片的下载，当前正在进行的单次读取不会被打断
+func (f *Fetcher) Pause() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.paused {
+		return
+	}
+	f.paused = true
+	f.pause = make(chan struct{})
+	close(f.pause)
+}
+
+// Resume 恢复一个已暂停的下载
+func (f *Fetcher) Resume() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.paused {
+		return
+	}
+	f.paused = false
+	close(f.resume)
+	f.resume = make(chan struct{})
+	f.pause = nil
+}
+
+// Cancel 取消下载，已落盘的分片进度仍保留在 .gpd 文件中以便下次恢复
+func (f *Fetcher) Cancel() {
+	if f.cancel != nil {
+		f.cancel()
+	}
+}
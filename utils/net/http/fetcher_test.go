@@ -0,0 +1,223 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func rangeServer(t *testing.T, data []byte) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Accept-Ranges", "bytes")
+
+		rng := r.Header.Get("Range")
+		if rng == "" {
+			w.Write(data)
+			return
+		}
+
+		var start, end int
+		fmt.Sscanf(rng, "bytes=%d-%d", &start, &end)
+		if end >= len(data) {
+			end = len(data) - 1
+		}
+
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(data)))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(data[start : end+1])
+	}))
+}
+
+func nonRangeServer(t *testing.T, data []byte) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	}))
+}
+
+func TestFetcherStartWithRanges(t *testing.T) {
+	data := []byte(strings.Repeat("abcdefghij", 1024))
+	server := rangeServer(t, data)
+	defer server.Close()
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "out.bin")
+
+	var lastDownloaded, lastTotal int64
+	fetcher := NewFetcher(server.URL, target, 4, 30, func(downloaded, total int64) {
+		lastDownloaded, lastTotal = downloaded, total
+	})
+
+	if err := fetcher.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	got, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("downloaded content mismatch, got %d bytes want %d bytes", len(got), len(data))
+	}
+	if lastDownloaded != lastTotal {
+		t.Fatalf("progress callback reported %d/%d, want fully complete", lastDownloaded, lastTotal)
+	}
+	if _, err := os.Stat(target + ".gpd"); !os.IsNotExist(err) {
+		t.Fatalf(".gpd sidecar should be removed after a successful download")
+	}
+}
+
+func TestFetcherStartWithoutRanges(t *testing.T) {
+	data := []byte("no ranges supported here")
+	server := nonRangeServer(t, data)
+	defer server.Close()
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "out.bin")
+
+	fetcher := NewFetcher(server.URL, target, 4, 30, nil)
+	if err := fetcher.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	got, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("fallback download content mismatch, got %q want %q", got, data)
+	}
+}
+
+func TestFetcherResumeAfterTruncation(t *testing.T) {
+	data := []byte(strings.Repeat("0123456789", 2048))
+	server := rangeServer(t, data)
+	defer server.Close()
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "out.bin")
+
+	first := NewFetcher(server.URL, target, 4, 30, nil)
+	if err := first.probe(); err != nil {
+		t.Fatalf("probe() error = %v", err)
+	}
+	first.state = first.buildState()
+
+	file, err := os.OpenFile(target, os.O_RDWR|os.O_CREATE, 0o666)
+	if err != nil {
+		t.Fatalf("OpenFile() error = %v", err)
+	}
+	if err := file.Truncate(first.size); err != nil {
+		t.Fatalf("Truncate() error = %v", err)
+	}
+	// simulate an interrupted run: only the first chunk finished downloading
+	first0 := first.state.Chunks[0]
+	if _, err := file.WriteAt(data[first0.Offset:first0.End], first0.Offset); err != nil {
+		t.Fatalf("WriteAt() error = %v", err)
+	}
+	first.state.Chunks[0].Downloaded = first0.End - first0.Offset
+	file.Close()
+	if err := first.saveState(); err != nil {
+		t.Fatalf("saveState() error = %v", err)
+	}
+
+	second := NewFetcher(server.URL, target, 4, 30, nil)
+	if err := second.Start(context.Background()); err != nil {
+		t.Fatalf("resumed Start() error = %v", err)
+	}
+
+	got, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("resumed content mismatch, got %d bytes want %d bytes", len(got), len(data))
+	}
+}
+
+func TestFetcherCancelMidDownload(t *testing.T) {
+	const size = 10 * 1024 * 1024
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Accept-Ranges", "bytes")
+		rng := r.Header.Get("Range")
+		if rng == "" {
+			return
+		}
+		var start, end int
+		fmt.Sscanf(rng, "bytes=%d-%d", &start, &end)
+		if end >= size {
+			end = size - 1
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, size))
+		w.WriteHeader(http.StatusPartialContent)
+		flusher, _ := w.(http.Flusher)
+		chunk := make([]byte, 4096)
+		for n := start; n <= end; n += len(chunk) {
+			w.Write(chunk)
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "out.bin")
+
+	started := make(chan struct{})
+	var once sync.Once
+	fetcher := NewFetcher(server.URL, target, 4, 30, func(downloaded, total int64) {
+		once.Do(func() { close(started) })
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- fetcher.Start(ctx)
+	}()
+
+	// cancel only after the first bytes of the chunks have been written
+	select {
+	case <-started:
+	case err := <-done:
+		t.Fatalf("Start() returned before any progress: %v", err)
+	}
+	fetcher.Cancel()
+
+	err := <-done
+	if err == nil {
+		t.Fatalf("Start() after Cancel() should return an error, got nil")
+	}
+}
+
+func TestFetcherPauseResume(t *testing.T) {
+	data := []byte(strings.Repeat("x", 10*1024))
+	server := rangeServer(t, data)
+	defer server.Close()
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "out.bin")
+
+	fetcher := NewFetcher(server.URL, target, 2, 30, nil)
+	fetcher.Pause()
+	fetcher.Resume()
+
+	if err := fetcher.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	got, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if len(got) != len(data) {
+		t.Fatalf("got %d bytes, want %d", len(got), len(data))
+	}
+}
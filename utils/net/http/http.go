@@ -2,7 +2,7 @@ package http
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"fmt"
 	"io"
 	"mime"
@@ -15,8 +15,17 @@ import (
 	pbar "github.com/cheggaaa/pb/v3"
 )
 
+// Get 通过包级默认 Client 发起请求，等价于 DefaultClient.Get
 func Get(url string, msgs ...map[string]string) ([]byte, error) {
-	req, err := http.NewRequest("GET", url, nil)
+	return DefaultClient.Get(url, msgs...)
+}
+
+// Get 向 url 发起一次 GET 请求并返回完整响应体，msgs[0] 可选传入自定义请求头
+func (c *Client) Get(url string, msgs ...map[string]string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*defaultTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +39,7 @@ func Get(url string, msgs ...map[string]string) ([]byte, error) {
 		req.Header.Set(key, val)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.http.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -54,13 +63,71 @@ func Get(url string, msgs ...map[string]string) ([]byte, error) {
 	return result.Bytes(), nil
 }
 
+// HeadInfo 是 Head 探测请求返回的资源基本信息
+type HeadInfo struct {
+	ContentLength int64
+	AcceptsRanges bool
+}
+
+// Head 通过包级默认 Client 探测资源信息，等价于 DefaultClient.Head
+func Head(uri string, msgs ...map[string]string) (*HeadInfo, error) {
+	return DefaultClient.Head(uri, msgs...)
+}
+
+// Head 通过 0-0 的 Range 请求探测资源大小及服务器是否支持 Range，
+// 不下载正文，供断点续传判断与 download 包的 Resolve 阶段使用；msgs[0] 可选传入自定义请求头
+func (c *Client) Head(uri string, msgs ...map[string]string) (*HeadInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*defaultTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) > 0 {
+		for key, val := range msgs[0] {
+			req.Header.Set(key, val)
+		}
+	}
+	req.Header.Set("Range", "bytes=0-0")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	info := &HeadInfo{
+		AcceptsRanges: resp.StatusCode == http.StatusPartialContent && resp.Header.Get("Accept-Ranges") != "none",
+	}
+
+	var total int64
+	if cr := resp.Header.Get("Content-Range"); cr != "" {
+		fmt.Sscanf(cr, "bytes 0-0/%d", &total)
+	}
+	if total == 0 {
+		if resp.StatusCode == http.StatusOK {
+			total = resp.ContentLength
+		} else {
+			total = resp.ContentLength + 1
+		}
+	}
+	info.ContentLength = total
+	return info, nil
+}
+
+// Download 通过包级默认 Client 下载，等价于 DefaultClient.Download
+func Download(uri string, target string, msgs ...interface{}) (map[string]interface{}, error) {
+	return DefaultClient.Download(uri, target, msgs...)
+}
+
 /**
 * 远程文件下载，支持断点续传，支持实时进度显示
 * @param string uri 远程资源地址
 * @param string target 调用时传入文件名，如果支持断点续传时当程序超时程序会自动调用该方法重新下载，此时传入的是文件句柄
 * @param interface{} msgs 可变参数，参数顺序 0: retry int（下载失败后重试次数） 1：timeout int 超时，默认300s 2：progressbar bool 是否开启进度条，默认false
  */
-func Download(uri string, target string, msgs ...interface{}) (map[string]interface{}, error) {
+func (c *Client) Download(uri string, target string, msgs ...interface{}) (map[string]interface{}, error) {
 	filename := filepath.Base(target)
 	entension := filepath.Ext(target)
 	var targetDir string
@@ -94,10 +161,10 @@ func Download(uri string, target string, msgs ...interface{}) (map[string]interf
 		progressbar = msgs[2].(bool)
 	}
 
-	hresp, err := http.Get(uri)
+	hresp, err := c.http.Get(uri)
 	if err != nil {
 		if retry > 0 {
-			return Download(uri, target, retry-1, timeout, progressbar)
+			return c.Download(uri, target, retry-1, timeout, progressbar)
 		} else {
 			return nil, fmt.Errorf("Failed to get response header, Error message → ", err.Error())
 		}
@@ -135,7 +202,7 @@ func Download(uri string, target string, msgs ...interface{}) (map[string]interf
 		} else {
 			if err := os.Remove(target); err != nil {
 				if retry > 0 {
-					return Download(uri, target, retry-1, timeout, progressbar)
+					return c.Download(uri, target, retry-1, timeout, progressbar)
 				} else {
 					return nil, err
 				}
@@ -151,10 +218,13 @@ func Download(uri string, target string, msgs ...interface{}) (map[string]interf
 		return res, nil
 	}
 
-	req, err := http.NewRequest("GET", uri, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(timeout))
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", uri, nil)
 	if err != nil {
 		if retry > 0 {
-			return Download(uri, target, retry-1, timeout, progressbar)
+			return c.Download(uri, target, retry-1, timeout, progressbar)
 		} else {
 			return nil, err
 		}
@@ -166,17 +236,10 @@ func Download(uri string, target string, msgs ...interface{}) (map[string]interf
 		req.Header.Set("Range", fmt.Sprintf("bytes=%v-", size))
 	}
 
-	client := &http.Client{
-		Timeout: time.Second * time.Duration(timeout),
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		},
-	}
-
-	resp, err := client.Do(req)
+	resp, err := c.http.Do(req)
 	if err != nil {
 		if retry > 0 {
-			return Download(uri, target, retry-1, timeout, progressbar)
+			return c.Download(uri, target, retry-1, timeout, progressbar)
 		} else {
 			return nil, err
 		}
@@ -185,16 +248,37 @@ func Download(uri string, target string, msgs ...interface{}) (map[string]interf
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		if retry > 0 {
-			return Download(uri, target, retry-1, timeout, progressbar)
+			return c.Download(uri, target, retry-1, timeout, progressbar)
 		} else {
 			return nil, fmt.Errorf("Http request was not successfully received and processed, status code is %v, status is %v", resp.StatusCode, resp.Status)
 		}
 	}
 
+	var bodyReader io.Reader = resp.Body
+	if size == 0 {
+		head := make([]byte, 512)
+		n, rerr := io.ReadFull(resp.Body, head)
+		if rerr != nil && rerr != io.ErrUnexpectedEOF && rerr != io.EOF {
+			if retry > 0 {
+				return c.Download(uri, target, retry-1, timeout, progressbar)
+			} else {
+				return nil, rerr
+			}
+		}
+		head = head[:n]
+		bodyReader = io.MultiReader(bytes.NewReader(head), resp.Body)
+
+		if detected := helper.DetectFileType(head); detected != "" && detected != entension {
+			entension = detected
+			filename = fmt.Sprintf("%s%s", strings.TrimSuffix(filename, filepath.Ext(filename)), entension)
+			target = fmt.Sprintf("%s/%s", targetDir, filename)
+		}
+	}
+
 	file, err := os.OpenFile(target, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0666)
 	if err != nil {
 		if retry > 0 {
-			return Download(uri, target, retry-1, timeout, progressbar)
+			return c.Download(uri, target, retry-1, timeout, progressbar)
 		} else {
 			return nil, err
 		}
@@ -202,23 +286,23 @@ func Download(uri string, target string, msgs ...interface{}) (map[string]interf
 	defer file.Close()
 
 	if progressbar {
-		reader := io.LimitReader(io.MultiReader(resp.Body), int64(resp.ContentLength))
+		reader := io.LimitReader(bodyReader, int64(resp.ContentLength))
 		bar := pbar.Full.Start64(resp.ContentLength)
 		barReader := bar.NewProxyReader(reader)
 		_, err := io.Copy(file, barReader)
 		bar.Finish()
 		if err != nil {
 			if retry > 0 {
-				return Download(uri, target, retry-1, timeout, progressbar)
+				return c.Download(uri, target, retry-1, timeout, progressbar)
 			} else {
 				return nil, err
 			}
 		}
 	} else {
-		_, err = io.Copy(file, resp.Body)
+		_, err = io.Copy(file, bodyReader)
 		if err != nil {
 			if retry > 0 {
-				return Download(uri, target, retry-1, timeout, progressbar)
+				return c.Download(uri, target, retry-1, timeout, progressbar)
 			} else {
 				return nil, err
 			}